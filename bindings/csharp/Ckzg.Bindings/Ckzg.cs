@@ -28,7 +28,7 @@ public class Ckzg
     /// <param name="ts">Trusted setup settings</param>
     /// <returns>Returns error code or <c>0</c> if successful</returns>
     [DllImport("ckzg", EntryPoint = "blob_to_kzg_commitment_wrap", CallingConvention = CallingConvention.Cdecl)]
-    public unsafe static extern int BlobToKzgCommitment(byte* commitment, byte* blob, IntPtr ts);
+    public unsafe static extern int BlobToKzgCommitment(byte* commitment, byte* blob, KzgSettingsHandle ts);
 
 
     /// <summary>
@@ -40,7 +40,7 @@ public class Ckzg
     /// <param name="ts">Trusted setup settings</param>
     /// <returns>Returns error code or <c>0</c> if successful</returns>
     [DllImport("ckzg", EntryPoint = "compute_aggregate_kzg_proof_wrap", CallingConvention = CallingConvention.Cdecl)] // returns 0 on success
-    public unsafe static extern int ComputeAggregatedKzgProof(byte* proof, byte* blobs, int count, IntPtr ts);
+    public unsafe static extern int ComputeAggregatedKzgProof(byte* proof, byte* blobs, int count, KzgSettingsHandle ts);
 
 
     /// <summary>
@@ -53,7 +53,7 @@ public class Ckzg
     /// <param name="ts">Trusted setup settings</param>
     /// <returns>Returns error code or <c>0</c> if the proof is correct</returns>
     [DllImport("ckzg", EntryPoint = "verify_aggregate_kzg_proof_wrap", CallingConvention = CallingConvention.Cdecl)] // returns 0 on success
-    public unsafe static extern int VerifyAggregatedKzgProof(byte* blobs, byte* commitments, int count, byte* proof, IntPtr ts);
+    public unsafe static extern int VerifyAggregatedKzgProof(byte* blobs, byte* commitments, int count, byte* proof, KzgSettingsHandle ts);
 
     /// <summary>
     /// Verify the proof by point evaluation for the given commitment
@@ -65,21 +65,23 @@ public class Ckzg
     /// <param name="ts">Trusted setup settings</param>
     /// <returns>Returns error code or <c>0</c> if the proof is correct</returns>
     [DllImport("ckzg", EntryPoint = "verify_kzg_proof_wrap", CallingConvention = CallingConvention.Cdecl)] // returns 0 on success
-    public unsafe static extern int VerifyKzgProof(byte* commitment, byte* z, byte* y, byte* proof, IntPtr ts);
+    public unsafe static extern int VerifyKzgProof(byte* commitment, byte* z, byte* y, byte* proof, KzgSettingsHandle ts);
 
     /// <summary>
     /// Load trusted setup settings from file
     /// </summary>
     /// <param name="filename">Settings file path</param>
-    /// <returns>Trusted setup settings as a pointer or <c>0</c> in case of failure</returns>
-    [DllImport("ckzg", EntryPoint = "load_trusted_setup_wrap")] // free result with free_trusted_setup()
-    public static extern IntPtr LoadTrustedSetup(string filename);
+    /// <returns>Trusted setup settings handle, invalid in case of failure</returns>
+    [DllImport("ckzg", EntryPoint = "load_trusted_setup_wrap")] // free result with FreeTrustedSetup()
+    public static extern KzgSettingsHandle LoadTrustedSetup(string filename);
 
     /// <summary>
-    /// Frees memory allocated for trusted setup settings
+    /// Frees memory allocated for trusted setup settings. Safe to call more than once, including concurrently
     /// </summary>
     /// <param name="ts">Trusted setup settings</param>
+    public static void FreeTrustedSetup(KzgSettingsHandle ts) => ts.Dispose();
+
     [DllImport("ckzg", EntryPoint = "free_trusted_setup_wrap", CallingConvention = CallingConvention.Cdecl)]
-    public static extern void FreeTrustedSetup(IntPtr ts);
+    internal static extern void FreeTrustedSetupNative(IntPtr ts);
 }
 
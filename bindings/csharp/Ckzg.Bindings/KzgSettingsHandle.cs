@@ -0,0 +1,23 @@
+using Microsoft.Win32.SafeHandles;
+
+namespace Ckzg;
+
+/// <summary>
+/// Owns trusted setup settings loaded by <see cref="Ckzg.LoadTrustedSetup"/>
+/// </summary>
+/// <remarks>
+/// The settings are freed at most once, even if the handle is disposed several times or from several threads.
+/// Passing a disposed handle to a binding throws <see cref="ObjectDisposedException"/>.
+/// </remarks>
+public sealed class KzgSettingsHandle : SafeHandleZeroOrMinusOneIsInvalid
+{
+    public KzgSettingsHandle() : base(true)
+    {
+    }
+
+    protected override bool ReleaseHandle()
+    {
+        Ckzg.FreeTrustedSetupNative(handle);
+        return true;
+    }
+}
@@ -5,13 +5,13 @@ namespace Ckzg.Test;
 [TestFixture]
 public class BasicKzgTests
 {
-    private IntPtr _ts;
+    private KzgSettingsHandle _ts = null!;
 
     [SetUp]
     public void Setup()
     {
         _ts = Ckzg.LoadTrustedSetup("trusted_setup.txt");
-        Assert.That(_ts, Is.Not.EqualTo(IntPtr.Zero));
+        Assert.That(_ts.IsInvalid, Is.False);
     }
 
     [TestCase(0xff, 1, -1)]
@@ -54,4 +54,44 @@ public class BasicKzgTests
             Assert.That(result, Is.EqualTo(0));
         }
     }
+
+    [TestCase]
+    public void Test_FreeTrustedSetup_Twice()
+    {
+        Ckzg.FreeTrustedSetup(_ts);
+        Ckzg.FreeTrustedSetup(_ts);
+        Assert.That(_ts.IsClosed, Is.True);
+    }
+
+    [TestCase]
+    public void Test_FreeTrustedSetup_Concurrently()
+    {
+        Parallel.For(0, 16, _ => Ckzg.FreeTrustedSetup(_ts));
+        Assert.That(_ts.IsClosed, Is.True);
+    }
+
+    [TestCase]
+    public unsafe void Test_UsingFreedTrustedSetup_Throws()
+    {
+        Ckzg.FreeTrustedSetup(_ts);
+
+        byte[] blob = new byte[Ckzg.BlobLength];
+        byte[] commitment = new byte[Ckzg.CommitmentLength];
+        fixed (byte* commitmentPtr = commitment, blobPtr = blob)
+        {
+            byte* c = commitmentPtr, b = blobPtr;
+            Assert.Throws<ObjectDisposedException>(() => Ckzg.BlobToKzgCommitment(c, b, _ts));
+        }
+    }
+
+    [TestCase]
+    public void Test_FreeTrustedSetup_AfterFailedLoad()
+    {
+        Ckzg.FreeTrustedSetup(_ts);
+
+        KzgSettingsHandle ts = Ckzg.LoadTrustedSetup("missing_trusted_setup.txt");
+        Assert.That(ts.IsInvalid, Is.True);
+        Ckzg.FreeTrustedSetup(ts);
+        Ckzg.FreeTrustedSetup(ts);
+    }
 }
\ No newline at end of file
@@ -44,7 +44,7 @@ JNIEXPORT jint JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_getFieldElementsPerBlo
   return (jint)FIELD_ELEMENTS_PER_BLOB;
 }
 
-JNIEXPORT void JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_loadTrustedSetup__Ljava_lang_String_2(JNIEnv *env, jclass thisCls, jstring file)
+void load_trusted_setup_from_file(JNIEnv *env, jstring file)
 {
   if (settings)
   {
@@ -52,7 +52,7 @@ JNIEXPORT void JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_loadTrustedSetup__Ljav
     return;
   }
 
-  settings = malloc(sizeof(KZGSettings));
+  settings = calloc(1, sizeof(KZGSettings));
   if (settings == NULL)
   {
     throw_exception(env, "Failed to allocate memory for the Trusted Setup.");
@@ -84,7 +84,7 @@ JNIEXPORT void JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_loadTrustedSetup__Ljav
   }
 }
 
-JNIEXPORT void JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_loadTrustedSetup___3BJ_3BJ(JNIEnv *env, jclass thisCls, jbyteArray g1, jlong g1Count, jbyteArray g2, jlong g2Count)
+void load_trusted_setup_from_bytes(JNIEnv *env, jbyteArray g1, jlong g1Count, jbyteArray g2, jlong g2Count)
 {
   if (settings)
   {
@@ -92,7 +92,7 @@ JNIEXPORT void JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_loadTrustedSetup___3BJ
     return;
   }
 
-  settings = malloc(sizeof(KZGSettings));
+  settings = calloc(1, sizeof(KZGSettings));
   if (settings == NULL)
   {
     throw_exception(env, "Failed to allocate memory for the Trusted Setup.");
@@ -115,14 +115,33 @@ JNIEXPORT void JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_loadTrustedSetup___3BJ
   }
 }
 
+JNIEXPORT void JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_loadTrustedSetup__Ljava_lang_String_2(JNIEnv *env, jclass thisCls, jstring file)
+{
+  // Loading and freeing hold the class monitor so concurrent calls can't race on settings
+  (*env)->MonitorEnter(env, thisCls);
+  load_trusted_setup_from_file(env, file);
+  (*env)->MonitorExit(env, thisCls);
+}
+
+JNIEXPORT void JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_loadTrustedSetup___3BJ_3BJ(JNIEnv *env, jclass thisCls, jbyteArray g1, jlong g1Count, jbyteArray g2, jlong g2Count)
+{
+  (*env)->MonitorEnter(env, thisCls);
+  load_trusted_setup_from_bytes(env, g1, g1Count, g2, g2Count);
+  (*env)->MonitorExit(env, thisCls);
+}
+
 JNIEXPORT void JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_freeTrustedSetup(JNIEnv *env, jclass thisCls)
 {
+  (*env)->MonitorEnter(env, thisCls);
   if (settings == NULL)
   {
     throw_exception(env, TRUSTED_SETUP_NOT_LOADED);
-    return;
   }
-  reset_trusted_setup();
+  else
+  {
+    reset_trusted_setup();
+  }
+  (*env)->MonitorExit(env, thisCls);
 }
 
 JNIEXPORT jbyteArray JNICALL Java_ethereum_ckzg4844_CKZG4844JNI_computeAggregateKzgProof(JNIEnv *env, jclass thisCls, jbyteArray blobs, jlong count)
@@ -109,7 +109,8 @@ public class CKZG4844JNI {
 
   /**
    * Free the current trusted setup. This method will throw an exception if no trusted setup has
-   * been loaded.
+   * been loaded. Loading and freeing are synchronized, so when called concurrently only one call
+   * frees the setup and the others throw.
    */
   public static native void freeTrustedSetup();
 
@@ -7,8 +7,16 @@ import static org.junit.jupiter.api.Assertions.assertTrue;
 
 import ethereum.ckzg4844.CKZG4844JNI.Preset;
 import ethereum.ckzg4844.CKZGException.CKZGError;
+import java.util.Arrays;
+import java.util.List;
 import java.util.Map;
 import java.util.Optional;
+import java.util.concurrent.CountDownLatch;
+import java.util.concurrent.ExecutorService;
+import java.util.concurrent.Executors;
+import java.util.concurrent.Future;
+import java.util.concurrent.TimeUnit;
+import java.util.stream.Collectors;
 import java.util.stream.IntStream;
 import java.util.stream.Stream;
 import org.junit.jupiter.api.Test;
@@ -167,7 +175,78 @@ public class CKZG4844JNITest {
 
   }
 
-  private void assertExceptionIsTrustedSetupIsNotLoaded(final RuntimeException exception) {
+  @Test
+  public void freesTrustedSetupOnlyOnceWhenCalledConcurrently() throws Exception {
+
+    loadTrustedSetup();
+
+    final int threads = 8;
+    final ExecutorService executor = Executors.newFixedThreadPool(threads);
+    final CountDownLatch start = new CountDownLatch(1);
+    try {
+      final List<Future<Boolean>> results = IntStream.range(0, threads)
+          .mapToObj(i -> executor.submit(() -> {
+            start.await();
+            try {
+              CKZG4844JNI.freeTrustedSetup();
+              return true;
+            } catch (final RuntimeException ex) {
+              assertExceptionIsTrustedSetupIsNotLoaded(ex);
+              return false;
+            }
+          }))
+          .collect(Collectors.toList());
+      start.countDown();
+
+      int freed = 0;
+      for (final Future<Boolean> result : results) {
+        if (result.get(1, TimeUnit.MINUTES)) {
+          freed++;
+        }
+      }
+      assertEquals(1, freed);
+    } finally {
+      executor.shutdownNow();
+    }
+
+  }
+
+  @Test
+  public void canLoadTrustedSetupAfterAFailedLoad() {
+
+    final RuntimeException missingFileException = assertThrows(RuntimeException.class,
+        () -> CKZG4844JNI.loadTrustedSetup("missing_trusted_setup.txt"));
+    assertEquals(
+        "Couldn't load Trusted Setup. File might not exist or there is a permission issue.",
+        missingFileException.getMessage());
+
+    final LoadTrustedSetupParameters parameters = TestUtils.createLoadTrustedSetupParameters(
+        TRUSTED_SETUP_FILE_BY_PRESET.get(PRESET));
+
+    // An invalid G1 point fails before the FFT settings are allocated
+    final byte[] invalidG1 = new byte[48];
+    Arrays.fill(invalidG1, (byte) 0xff);
+    CKZGException exception = assertThrows(CKZGException.class,
+        () -> CKZG4844JNI.loadTrustedSetup(invalidG1, 1, parameters.getG2(),
+            parameters.getG2Count()));
+    assertEquals(CKZGError.C_KZG_BADARGS, exception.getError());
+
+    // A count that is not a power of two fails in the FFT after the FFT settings are allocated
+    exception = assertThrows(CKZGException.class,
+        () -> CKZG4844JNI.loadTrustedSetup(Arrays.copyOf(parameters.getG1(), 3 * 48), 3,
+            parameters.getG2(), parameters.getG2Count()));
+    assertEquals(CKZGError.C_KZG_BADARGS, exception.getError());
+
+    final RuntimeException notLoadedException = assertThrows(RuntimeException.class,
+        CKZG4844JNI::freeTrustedSetup);
+    assertExceptionIsTrustedSetupIsNotLoaded(notLoadedException);
+
+    loadTrustedSetup();
+    CKZG4844JNI.freeTrustedSetup();
+
+  }
+
+  private static void assertExceptionIsTrustedSetupIsNotLoaded(final RuntimeException exception) {
     assertEquals("Trusted Setup is not loaded.", exception.getMessage());
   }
 
@@ -67,6 +67,16 @@ const uint8_t * extract_byte_array_from_param(const Napi::CallbackInfo& info, co
   return param.As<Napi::Uint8Array>().Data();
 }
 
+KZGSettings * extract_kzg_settings_from_param(const Napi::CallbackInfo& info, const int index) {
+  auto kzg_settings = info[index].As<Napi::External<KZGSettings>>().Data();
+  // freeTrustedSetup leaves the handle allocated with its contents reset to NULL
+  if (kzg_settings->fs == NULL) {
+    Napi::Error::New(info.Env(), "Trusted setup has been freed").ThrowAsJavaScriptException();
+    return NULL;
+  }
+  return kzg_settings;
+}
+
 
 // loadTrustedSetup: (filePath: string) => SetupHandle;
 Napi::Value LoadTrustedSetup(const Napi::CallbackInfo& info) {
@@ -105,7 +115,15 @@ Napi::Value LoadTrustedSetup(const Napi::CallbackInfo& info) {
     return env.Null();
   }
 
-  return Napi::External<KZGSettings>::New(info.Env(), kzg_settings);
+  // The handle itself is only released once it is garbage collected, so freeing it twice is harmless
+  return Napi::External<KZGSettings>::New(
+    info.Env(),
+    kzg_settings,
+    [](Napi::Env /*env*/, KZGSettings* kzg_settings) {
+      free_trusted_setup(kzg_settings);
+      free(kzg_settings);
+    }
+  );
 }
 
 // freeTrustedSetup: (setupHandle: SetupHandle) => void;
@@ -120,7 +138,6 @@ Napi::Value FreeTrustedSetup(const Napi::CallbackInfo& info) {
 
   auto kzg_settings = info[0].As<Napi::External<KZGSettings>>().Data();
   free_trusted_setup(kzg_settings);
-  free(kzg_settings);
   return env.Undefined();
 }
 
@@ -139,7 +156,10 @@ Napi::Value BlobToKzgCommitment(const Napi::CallbackInfo& info) {
     return env.Null();
   }
 
-  auto kzg_settings = info[1].As<Napi::External<KZGSettings>>().Data();
+  auto kzg_settings = extract_kzg_settings_from_param(info, 1);
+  if (env.IsExceptionPending()) {
+    return env.Null();
+  }
 
   KZGCommitment commitment;
   C_KZG_RET ret = blob_to_kzg_commitment(&commitment, blob, kzg_settings);
@@ -165,7 +185,10 @@ Napi::Value ComputeAggregateKzgProof(const Napi::CallbackInfo& info) {
   }
 
   auto blobs_param = info[0].As<Napi::Array>();
-  auto kzg_settings = info[1].As<Napi::External<KZGSettings>>().Data();
+  auto kzg_settings = extract_kzg_settings_from_param(info, 1);
+  if (env.IsExceptionPending()) {
+    return env.Null();
+  }
 
   auto blobs_count = blobs_param.Length();
 
@@ -210,7 +233,10 @@ Napi::Value VerifyAggregateKzgProof(const Napi::CallbackInfo& info) {
   auto blobs_param = info[0].As<Napi::Array>();
   auto commitments_param = info[1].As<Napi::Array>();
   auto proof_param = info[2].As<Napi::TypedArray>();
-  auto kzg_settings = info[3].As<Napi::External<KZGSettings>>().Data();
+  auto kzg_settings = extract_kzg_settings_from_param(info, 3);
+  if (env.IsExceptionPending()) {
+    return env.Null();
+  }
 
   auto proof_bytes = proof_param.As<Napi::Uint8Array>().Data();
   auto blobs_count = blobs_param.Length();
@@ -297,12 +323,16 @@ Napi::Value VerifyKzgProof(const Napi::CallbackInfo& info) {
   auto z = extract_byte_array_from_param(info, 1, "z");
   auto y = extract_byte_array_from_param(info, 2, "y");
   auto kzg_proof = extract_byte_array_from_param(info, 3, "kzgProof");
-  auto kzg_settings = info[4].As<Napi::External<KZGSettings>>().Data();
 
   if (env.IsExceptionPending()) {
     return env.Null();
   }
 
+  auto kzg_settings = extract_kzg_settings_from_param(info, 4);
+  if (env.IsExceptionPending()) {
+    return env.Null();
+  }
+
   KZGCommitment commitment;
   auto ret = bytes_to_g1(&commitment, polynomial_kzg);
   if (ret != C_KZG_OK) {
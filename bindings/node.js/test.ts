@@ -108,6 +108,21 @@ describe("C-KZG", () => {
     ).toThrowError("Invalid commitment data");
   });
 
+  describe("freeing the trusted setup", () => {
+    // The native addon, to free a handle without the wrapper's loaded-state check
+    const kzg = require("./kzg.node");
+
+    it("can free the same setup handle twice", async () => {
+      const file = await transformTrustedSetupJSON(SETUP_FILE_PATH);
+      const handle = kzg.loadTrustedSetup(file);
+      kzg.freeTrustedSetup(handle);
+      kzg.freeTrustedSetup(handle);
+      expect(() =>
+        kzg.blobToKzgCommitment(generateRandomBlob(), handle),
+      ).toThrowError("Trusted setup has been freed");
+    });
+  });
+
   describe("computing commitment from blobs", () => {
     it("throws as expected when given an argument of invalid type", () => {
       // @ts-expect-error
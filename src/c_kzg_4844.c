@@ -812,6 +812,7 @@ C_KZG_RET load_trusted_setup(KZGSettings *out, const uint8_t g1_bytes[], size_t
     uint64_t i;
     blst_p2_affine g2_affine;
     g1_t *g1_projective = NULL;
+    bool fs_initialized = false;
     C_KZG_RET ret;
 
     out->fs = NULL;
@@ -842,6 +843,7 @@ C_KZG_RET load_trusted_setup(KZGSettings *out, const uint8_t g1_bytes[], size_t
     if (ret != C_KZG_OK) goto out_error;
     ret = new_fft_settings((FFTSettings*)out->fs, max_scale);
     if (ret != C_KZG_OK) goto out_error;
+    fs_initialized = true;
     ret = fft_g1(out->g1_values, g1_projective, true, n1, out->fs);
     if (ret != C_KZG_OK) goto out_error;
     ret = reverse_bit_order(out->g1_values, sizeof(g1_t), n1);
@@ -850,9 +852,14 @@ C_KZG_RET load_trusted_setup(KZGSettings *out, const uint8_t g1_bytes[], size_t
     goto out_success;
 
 out_error:
+    if (fs_initialized) free_fft_settings((FFTSettings*)out->fs);
     if (out->fs != NULL) free((void *)out->fs);
     if (out->g1_values != NULL) free(out->g1_values);
     if (out->g2_values != NULL) free(out->g2_values);
+    // Leave the settings safe to pass to #free_trusted_setup
+    out->fs = NULL;
+    out->g1_values = NULL;
+    out->g2_values = NULL;
 out_success:
     if (g1_projective != NULL) free(g1_projective);
     return ret;
@@ -862,6 +869,10 @@ C_KZG_RET load_trusted_setup_file(KZGSettings *out, FILE *in) {
     uint64_t i;
     int num_matches;
 
+    out->fs = NULL;
+    out->g1_values = NULL;
+    out->g2_values = NULL;
+
     num_matches = fscanf(in, "%" SCNu64, &i);
     CHECK(num_matches == 1);
     CHECK(i == FIELD_ELEMENTS_PER_BLOB);
@@ -885,9 +896,21 @@ C_KZG_RET load_trusted_setup_file(KZGSettings *out, FILE *in) {
     return load_trusted_setup(out, g1_bytes, FIELD_ELEMENTS_PER_BLOB, g2_bytes, 65);
 }
 
+/**
+ * Free the memory that was previously allocated by #load_trusted_setup.
+ *
+ * Does nothing if @p s is `NULL`, was already freed by this function, or failed to load in #load_trusted_setup or
+ * #load_trusted_setup_file. Concurrent calls on the same settings must be serialized by the caller.
+ *
+ * @param s The settings to be freed
+ */
 void free_trusted_setup(KZGSettings *s) {
-    free_fft_settings((FFTSettings*)s->fs);
+    if (s == NULL) return;
+    if (s->fs != NULL) free_fft_settings((FFTSettings*)s->fs);
     free_kzg_settings(s);
+    s->fs = NULL;
+    s->g1_values = NULL;
+    s->g2_values = NULL;
 }
 
 static void compute_powers(BLSFieldElement out[], BLSFieldElement *x, uint64_t n) {